@@ -0,0 +1,175 @@
+package trie
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Match is a single scored result from FuzzyRank. Positions holds the
+// rune indices into Key that were matched against the query, in
+// ascending order, so callers can highlight them.
+type Match struct {
+	Key       string
+	Score     int
+	Positions []int
+}
+
+// Scoring constants for FuzzyRank, modeled on the bonuses used by
+// gopls/fzf-style fuzzy matchers.
+const (
+	rankScoreMatch       = 16
+	rankBonusFirstRune   = 16
+	rankBonusBoundary    = 8
+	rankBonusConsecutive = 4
+	rankPenaltySkip      = 1
+	rankPenaltyGap       = 3
+)
+
+// optimisticBound returns the highest score any completion of st could
+// possibly reach with remaining query runes still to match. It assumes
+// every one of them matches consecutively, at a word boundary -- the
+// two bonuses that can recur on every remaining rune -- so the
+// rankBonusConsecutive streak term keeps growing the whole way rather
+// than being capped at a single rune's worth, the way a flat
+// per-rune constant would. Overestimating is fine: this is a prune
+// bound, and it must never fall below a score that's actually
+// reachable or FuzzyRank could discard the true best match.
+func optimisticBound(st rankState, remaining int) int {
+	bound := st.score + remaining*(rankScoreMatch+rankBonusBoundary)
+	bound += rankBonusConsecutive * (remaining*st.streak + remaining*(remaining+1)/2)
+	if st.lastDepth < 0 {
+		bound += rankBonusFirstRune
+	}
+	return bound
+}
+
+// matchHeap is a min-heap of Match ordered by ascending Score, used to
+// track the current k best candidates while ranking.
+type matchHeap []Match
+
+func (h matchHeap) Len() int            { return len(h) }
+func (h matchHeap) Less(i, j int) bool  { return h[i].Score < h[j].Score }
+func (h matchHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x interface{}) { *h = append(*h, x.(Match)) }
+func (h *matchHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// rankState tracks the progress of a single candidate path down the
+// trie while FuzzyRank searches for the best scoring matches.
+type rankState struct {
+	qi        int // index of the next query rune to match
+	score     int
+	positions []int
+	lastDepth int // key-rune depth of the last match, -1 if none yet
+	streak    int // length of the current run of consecutive matches
+}
+
+// FuzzyRank performs a scored fuzzy search against the keys in the
+// trie and returns at most k matches sorted by descending score.
+// Each matched rune contributes a positive score, with bonuses for
+// matching the first rune of a key, matching right after a word
+// boundary (-, _, /, ., or a lower->upper camel transition), and
+// extending a consecutive run, while skipped runes and gaps between
+// matches are penalized. Subtrees that cannot possibly beat the
+// current k-th best score are pruned.
+func (t Trie) FuzzyRank(query string, k int) []Match {
+	if k <= 0 || len(query) == 0 {
+		return nil
+	}
+
+	q := []rune(query)
+	h := &matchHeap{}
+	heap.Init(h)
+
+	st := rankState{lastDepth: -1}
+	rankcollect(t.Root(), nil, -1, q, st, k, h)
+
+	matches := make([]Match, h.Len())
+	for i := len(matches) - 1; i >= 0; i-- {
+		matches[i] = heap.Pop(h).(Match)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+func rankcollect(node *Node, pre []rune, depth int, query []rune, st rankState, k int, h *matchHeap) {
+	if remaining := len(query) - st.qi; remaining > 0 && h.Len() >= k {
+		if optimisticBound(st, remaining) <= (*h)[0].Score {
+			return
+		}
+	}
+
+	var prev rune
+	if depth >= 0 {
+		prev = node.Val()
+	}
+
+	for r, n := range node.Children() {
+		if n.term {
+			if st.qi == len(query) {
+				heap.Push(h, Match{Key: string(pre), Score: st.score, Positions: st.positions})
+				if h.Len() > k {
+					heap.Pop(h)
+				}
+			}
+			continue
+		}
+
+		// Prune on the child's own mask, not the current node's: the
+		// root never has its own mask populated, only its children do.
+		if remaining := len(query) - st.qi; remaining > 0 {
+			if m := maskruneslice(node.mode, query[st.qi:]); m&^n.Mask() != 0 {
+				continue
+			}
+		}
+
+		npre := append(pre, r)
+		nst := st
+
+		if st.qi < len(query) && r == query[st.qi] {
+			bonus := rankScoreMatch
+			if depth < 0 {
+				bonus += rankBonusFirstRune
+			}
+			if isWordBoundary(prev, r) {
+				bonus += rankBonusBoundary
+			}
+			if st.lastDepth == depth {
+				nst.streak = st.streak + 1
+				bonus += rankBonusConsecutive * nst.streak
+			} else {
+				nst.streak = 0
+				if st.lastDepth >= 0 {
+					bonus -= rankPenaltyGap
+				}
+			}
+
+			nst.qi = st.qi + 1
+			nst.score = st.score + bonus
+			nst.lastDepth = depth + 1
+			nst.positions = append(append([]int{}, st.positions...), depth+1)
+		} else {
+			nst.score = st.score - rankPenaltySkip
+		}
+
+		rankcollect(n, npre, depth+1, query, nst, k, h)
+	}
+}
+
+// isWordBoundary reports whether cur begins a new "word" following
+// prev, e.g. after a separator or on a lower->upper camel transition.
+func isWordBoundary(prev, cur rune) bool {
+	switch prev {
+	case 0, '-', '_', '/', '.':
+		return true
+	}
+	return isLower(prev) && isUpper(cur)
+}
+
+func isLower(r rune) bool { return r >= 'a' && r <= 'z' }
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
@@ -0,0 +1,82 @@
+package trie
+
+import (
+	"container/heap"
+	"testing"
+)
+
+func TestFuzzyRankFindsExactAndPrefixMatches(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"apple", "application", "apt", "banana"} {
+		tr.Add(w)
+	}
+
+	matches := tr.FuzzyRank("app", 5)
+	if len(matches) == 0 {
+		t.Fatalf("FuzzyRank returned no matches for a query present in the trie")
+	}
+
+	found := make(map[string]bool)
+	for _, m := range matches {
+		found[m.Key] = true
+	}
+	// "apt" has only one 'p', so "app" is not a subsequence of it and it
+	// must not appear; apple and application both contain "app" as a
+	// prefix.
+	if !found["apple"] || !found["application"] || found["apt"] {
+		t.Fatalf("FuzzyRank missed expected matches, got %+v", matches)
+	}
+
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Score > matches[i-1].Score {
+			t.Fatalf("FuzzyRank results not sorted by descending score: %+v", matches)
+		}
+	}
+}
+
+func TestFuzzyRankRespectsK(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"a", "ab", "abc", "abcd", "abcde"} {
+		tr.Add(w)
+	}
+
+	matches := tr.FuzzyRank("a", 2)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(matches), matches)
+	}
+}
+
+// TestFuzzyRankPruneBoundAccountsForGrowingStreak guards against the
+// prune bound underestimating a candidate that's deep into a long
+// consecutive-match streak: rankBonusConsecutive*streak grows without
+// limit, so a flat per-rune constant eventually understates what the
+// remaining runes can actually score, and the search wrongly discards
+// the true best match in favor of a lower-scoring one already in the
+// heap.
+func TestFuzzyRankPruneBoundAccountsForGrowingStreak(t *testing.T) {
+	tr := CreateTrie()
+	word := "aaaaaaaaaaaaaa" // 14 a's: an exact match builds an
+	// uninterrupted consecutive-match streak the whole way through.
+	tr.Add(word)
+	query := []rune(word)
+
+	// Walk 12 runes into the single-child chain Add created.
+	node := tr.Root()
+	for i := 0; i < 12; i++ {
+		node = node.Children()['a']
+	}
+
+	// Seed a full (k=1) heap with a score that the old flat, streak-
+	// oblivious bound judged unbeatable from here, even though
+	// finishing the remaining 2 runes -- each extending an already
+	// long streak -- actually scores higher than that.
+	h := &matchHeap{{Key: "decoy", Score: 650}}
+	heap.Init(h)
+
+	st := rankState{qi: 12, score: 550, streak: 12, lastDepth: 11}
+	rankcollect(node, []rune(word[:12]), 11, query, st, 1, h)
+
+	if h.Len() != 1 || (*h)[0].Key != word {
+		t.Fatalf("rankcollect pruned the true best match in favor of a lower-scoring decoy: heap = %+v", *h)
+	}
+}
@@ -0,0 +1,205 @@
+package trie
+
+// Put inserts value under key, overwriting any value already stored
+// there, and reports whether key was new to the trie.
+func (t *Trie) Put(key string, value any) bool {
+	n := t.putrune(t.Root(), []rune(key), value)
+	if n {
+		t.size++
+	}
+	return n
+}
+
+// putrune walks (and extends) the trie along runes, stashing value on
+// the terminal marker at the end of the path. It returns true if the
+// key was not already present.
+func (t *Trie) putrune(node *Node, runes []rune, value any) bool {
+	if len(runes) == 0 {
+		term, ok := node.children[0]
+		if !ok {
+			term = node.NewChild(node, 0, 0, nul, true)
+			term.value = value
+			return true
+		}
+		term.value = value
+		return false
+	}
+
+	r := runes[0]
+	bitmask := maskruneslice(node.mode, runes)
+
+	n, ok := node.children[r]
+	if !ok {
+		n = node.NewChild(node, r, bitmask, r, false)
+	}
+	n.mask |= bitmask
+
+	return t.putrune(n, runes[1:], value)
+}
+
+// Get returns the value stored under key, if any.
+func (t Trie) Get(key string) (any, bool) {
+	node := t.nodeAtPath(key)
+	if node == nil {
+		return nil, false
+	}
+
+	term, ok := node.children[0]
+	if !ok {
+		return nil, false
+	}
+	return term.value, true
+}
+
+// Delete removes key's value from the trie, collapsing the branch it
+// lived on the same way Remove does, and reports whether key was
+// present.
+func (t *Trie) Delete(key string) bool {
+	node := t.nodeAtPath(key)
+	if node == nil {
+		return false
+	}
+
+	if _, ok := node.children[0]; !ok {
+		return false
+	}
+	delete(node.children, 0)
+	node.recalculateMask()
+	for p := node.Parent(); p != nil; p = p.Parent() {
+		p.recalculateMask()
+	}
+	t.size--
+
+	if len(node.children) > 0 {
+		// Key is itself a prefix of other stored keys; keep the chain.
+		return true
+	}
+
+	rs := []rune(key)
+	i := 0
+	for n := node.Parent(); n != nil; n = n.Parent() {
+		i++
+		if len(n.Children()) > 1 {
+			idx := len(rs) - i
+			n.RemoveChild(rs[idx])
+			break
+		}
+	}
+	return true
+}
+
+// PrefixWalk calls fn for every key/value pair stored under pre, in
+// no particular order. Iteration stops early if fn returns false.
+func (t Trie) PrefixWalk(pre string, fn func(key string, val any) bool) {
+	node := t.nodeAtPath(pre)
+	if node == nil {
+		return
+	}
+	walk(node, []rune(pre), fn)
+}
+
+func walk(node *Node, pre []rune, fn func(key string, val any) bool) bool {
+	for r, n := range node.Children() {
+		if n.term {
+			if !fn(string(pre), n.value) {
+				return false
+			}
+			continue
+		}
+
+		npre := append(pre, r)
+		if !walk(n, npre, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// FuzzyWalk calls fn for every key/value pair that fuzzy-matches pre
+// under the same rule as FuzzySearch, in no particular order (unlike
+// FuzzySearch, it does not sort). Iteration stops early if fn returns
+// false.
+func (t Trie) FuzzyWalk(pre string, fn func(key string, val any) bool) {
+	fuzzywalk(t.Root(), nil, []rune(pre), fn)
+}
+
+func fuzzywalk(node *Node, partialmatch, partial []rune, fn func(key string, val any) bool) bool {
+	partiallen := len(partial)
+
+	if partiallen == 0 {
+		return walk(node, partialmatch, fn)
+	}
+
+	m := maskruneslice(node.mode, partial)
+	for v, n := range node.Children() {
+		xor := n.Mask() ^ m
+		if (xor & m) != 0 {
+			continue
+		}
+
+		npartial := partial
+		if v == partial[0] {
+			if partiallen > 1 {
+				npartial = partial[1:]
+			} else {
+				npartial = partial[0:0]
+			}
+		}
+
+		if !fuzzywalk(n, append(partialmatch, v), npartial, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// TypedTrie wraps a Trie to give callers who know V ahead of time a
+// type-safe Put/Get/Delete API, so the `(any, bool)` assertion from
+// Get lives in one place instead of at every call site. It still
+// stores values boxed in a Node, same as Trie.
+type TypedTrie[V any] struct {
+	t *Trie
+}
+
+// NewTypedTrie creates a new, empty TypedTrie[V].
+func NewTypedTrie[V any]() *TypedTrie[V] {
+	return &TypedTrie[V]{t: CreateTrie()}
+}
+
+// Put inserts value under key, overwriting any value already stored
+// there, and reports whether key was new to the trie.
+func (t *TypedTrie[V]) Put(key string, value V) bool {
+	return t.t.Put(key, value)
+}
+
+// Get returns the value stored under key, if any.
+func (t *TypedTrie[V]) Get(key string) (V, bool) {
+	v, ok := t.t.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(V), true
+}
+
+// Delete removes key's value from the trie and reports whether key
+// was present.
+func (t *TypedTrie[V]) Delete(key string) bool {
+	return t.t.Delete(key)
+}
+
+// PrefixWalk calls fn for every key/value pair stored under pre.
+// Iteration stops early if fn returns false.
+func (t *TypedTrie[V]) PrefixWalk(pre string, fn func(key string, val V) bool) {
+	t.t.PrefixWalk(pre, func(key string, val any) bool {
+		return fn(key, val.(V))
+	})
+}
+
+// FuzzyWalk calls fn for every key/value pair that fuzzy-matches pre.
+// Iteration stops early if fn returns false.
+func (t *TypedTrie[V]) FuzzyWalk(pre string, fn func(key string, val V) bool) {
+	t.t.FuzzyWalk(pre, func(key string, val any) bool {
+		return fn(key, val.(V))
+	})
+}
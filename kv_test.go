@@ -0,0 +1,76 @@
+package trie
+
+import "testing"
+
+func TestPutGetDelete(t *testing.T) {
+	tr := CreateTrie()
+
+	if isNew := tr.Put("hello", 42); !isNew {
+		t.Fatalf("Put reported existing key for a fresh key")
+	}
+	if isNew := tr.Put("hello", 43); isNew {
+		t.Fatalf("Put reported new key for an overwrite")
+	}
+
+	v, ok := tr.Get("hello")
+	if !ok || v.(int) != 43 {
+		t.Fatalf("Get(hello) = (%v, %v), want (43, true)", v, ok)
+	}
+
+	if _, ok := tr.Get("nope"); ok {
+		t.Fatalf("Get reported a value for a missing key")
+	}
+
+	if !tr.Delete("hello") {
+		t.Fatalf("Delete reported missing key for a present key")
+	}
+	if _, ok := tr.Get("hello"); ok {
+		t.Fatalf("Get still finds a value after Delete")
+	}
+	if tr.Delete("hello") {
+		t.Fatalf("Delete reported success for a key already removed")
+	}
+}
+
+func TestPrefixWalkAndFuzzyWalk(t *testing.T) {
+	tr := CreateTrie()
+	tr.Put("cat", 1)
+	tr.Put("car", 2)
+	tr.Put("dog", 3)
+
+	seen := map[string]any{}
+	tr.PrefixWalk("ca", func(key string, val any) bool {
+		seen[key] = val
+		return true
+	})
+	if len(seen) != 2 || seen["cat"] != 1 || seen["car"] != 2 {
+		t.Fatalf("PrefixWalk(ca) = %v, want cat:1 car:2", seen)
+	}
+
+	count := 0
+	tr.FuzzyWalk("ca", func(key string, val any) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Fatalf("FuzzyWalk did not stop after fn returned false, count = %d", count)
+	}
+}
+
+func TestTypedTrie(t *testing.T) {
+	tt := NewTypedTrie[string]()
+
+	tt.Put("hello", "world")
+	v, ok := tt.Get("hello")
+	if !ok || v != "world" {
+		t.Fatalf("Get(hello) = (%q, %v), want (world, true)", v, ok)
+	}
+
+	if _, ok := tt.Get("missing"); ok {
+		t.Fatalf("Get reported a value for a missing key")
+	}
+
+	if !tt.Delete("hello") {
+		t.Fatalf("Delete reported missing key for a present key")
+	}
+}
@@ -0,0 +1,126 @@
+package trie
+
+import (
+	"math/bits"
+	"sort"
+)
+
+type levResult struct {
+	key  string
+	dist int
+}
+
+// LevenshteinSearch returns every key within Damerau-Levenshtein
+// distance maxDist of query, sorted by ascending distance then
+// lexicographically. It walks the trie once, carrying a DP row per
+// node (the classic NFA-over-trie algorithm) rather than comparing
+// query against every stored key, and prunes any subtree whose row
+// minimum already exceeds maxDist, or whose Mask() is missing more of
+// query's distinct runes than the remaining edit budget allows.
+func (t Trie) LevenshteinSearch(query string, maxDist int) []string {
+	if maxDist < 0 {
+		return nil
+	}
+
+	q := []rune(query)
+	root := t.Root()
+
+	row := make([]int, len(q)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	qmask := maskruneslice(root.mode, q)
+
+	var results []levResult
+	levwalk(root, nil, row, nil, q, qmask, maxDist, 0, &results)
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].dist != results[j].dist {
+			return results[i].dist < results[j].dist
+		}
+		return results[i].key < results[j].key
+	})
+
+	keys := make([]string, len(results))
+	for i, res := range results {
+		keys[i] = res.key
+	}
+	return keys
+}
+
+// levwalk descends the trie computing DP rows. pathMask is the OR of
+// every rune already consumed on the path from the root down to (and
+// including) node: n.Mask() only covers n and its descendants, so
+// checking it alone against the *whole* query would wrongly treat
+// runes already matched higher up the path as missing, pruning real
+// matches (including the query itself) the moment they're consumed.
+func levwalk(node *Node, pre []rune, row, prevRow []int, query []rune, qmask uint64, maxDist int, pathMask uint64, results *[]levResult) {
+	priorRune := node.Val()
+
+	for r, n := range node.Children() {
+		if n.term {
+			if row[len(query)] <= maxDist {
+				*results = append(*results, levResult{key: string(pre), dist: row[len(query)]})
+			}
+			continue
+		}
+
+		if bits.OnesCount64(qmask&^(pathMask|n.Mask())) > maxDist {
+			continue
+		}
+
+		newRow := nextLevRow(row, prevRow, query, priorRune, r, len(pre)+1)
+		if minRow(newRow) > maxDist {
+			continue
+		}
+
+		levwalk(n, append(pre, r), newRow, row, query, qmask, maxDist, pathMask|maskrune(node.mode, r), results)
+	}
+}
+
+// nextLevRow computes the DP row for the path-so-far plus rune r,
+// given the row for the path without r (row) and the row one rune
+// further back (prevRow, for the Damerau transposition case).
+func nextLevRow(row, prevRow []int, query []rune, priorRune, r rune, depth int) []int {
+	newRow := make([]int, len(query)+1)
+	newRow[0] = depth
+
+	for i := 1; i <= len(query); i++ {
+		delCost := newRow[i-1] + 1
+		insCost := row[i] + 1
+		subCost := row[i-1]
+		if query[i-1] != r {
+			subCost++
+		}
+
+		best := delCost
+		if insCost < best {
+			best = insCost
+		}
+		if subCost < best {
+			best = subCost
+		}
+
+		if i >= 2 && prevRow != nil && priorRune != 0 &&
+			query[i-1] == priorRune && query[i-2] == r {
+			if transCost := prevRow[i-2] + 1; transCost < best {
+				best = transCost
+			}
+		}
+
+		newRow[i] = best
+	}
+
+	return newRow
+}
+
+func minRow(row []int) int {
+	m := row[0]
+	for _, v := range row[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,36 @@
+package trie
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLevenshteinSearchExactMatch(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"foo", "food", "fox", "fort", "bar"} {
+		tr.Add(w)
+	}
+
+	got := tr.LevenshteinSearch("foo", 0)
+	if !reflect.DeepEqual(got, []string{"foo"}) {
+		t.Fatalf("LevenshteinSearch(foo, 0) = %v, want [foo]", got)
+	}
+}
+
+func TestLevenshteinSearchWithinDistance(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"foo", "food", "fox", "fort", "bar"} {
+		tr.Add(w)
+	}
+
+	got := tr.LevenshteinSearch("foo", 1)
+	sort.Strings(got)
+
+	want := []string{"foo", "food", "fox"}
+	sort.Strings(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LevenshteinSearch(foo, 1) = %v, want %v", got, want)
+	}
+}
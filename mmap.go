@@ -0,0 +1,284 @@
+//go:build !windows
+
+package trie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+const (
+	mmapMagic      uint32 = 0x54524932 // "TRI2"
+	mmapVersion    uint8  = 2
+	mmapHeaderSize        = 4 + 1 + 1 + 4 + 4 + 4         // magic, version, mode, node count, edge count, root index
+	mmapNodeSize          = 4 + 1 + 8 + 4 + 4 + 1 + 4 + 4 // rune, term, mask, child-edge offset, child-edge count, has-value, value offset, value length
+	mmapEdgeSize          = 4 + 4                         // rune, node index
+)
+
+// SaveMmap serializes the trie to path in a packed array-of-nodes
+// layout, rather than WriteTo's nested pre-order stream: a header,
+// then one fixed-size record per node carrying a slice into a single
+// trailing (rune, node index) child-edge slab, followed by a trailing
+// slab of gob-encoded Values (for nodes with one set by Put). LoadMmap
+// reads files in this layout back via mmap, avoiding a plain file read
+// of the whole contents before parsing can start.
+//
+// If any stored value is of a concrete type other than the predeclared
+// basic types, call gob.Register on it before SaveMmap or LoadMmap so
+// encoding/gob can identify it; see gob.Register's documentation.
+func (t *Trie) SaveMmap(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	type nodeRec struct {
+		n          *Node
+		edges      [][2]uint32 // this node's own (rune, child index) pairs
+		childOff   uint32
+		childCount uint32
+		value      []byte
+	}
+
+	// Pass 1: assign every node a pre-order index and record its own
+	// direct children, without yet touching a shared edge slab -- the
+	// recursive descent below must finish walking a node's entire
+	// subtree before we know how many edges come after it in any
+	// shared slice, so edges have to be collected per-node first.
+	var recs []*nodeRec
+	var assign func(n *Node) uint32
+	assign = func(n *Node) uint32 {
+		idx := uint32(len(recs))
+		rec := &nodeRec{n: n}
+		recs = append(recs, rec)
+
+		for r, c := range n.children {
+			rec.edges = append(rec.edges, [2]uint32{uint32(r), assign(c)})
+		}
+		return idx
+	}
+	assign(t.root)
+
+	// Pass 2: lay every node's edges into one contiguous slab, in
+	// node order, now that each node's edge list is already complete.
+	var edges [][2]uint32
+	for _, rec := range recs {
+		rec.childOff = uint32(len(edges))
+		rec.childCount = uint32(len(rec.edges))
+		edges = append(edges, rec.edges...)
+	}
+
+	// Pass 3: gob-encode every node's value (if any) into a third slab,
+	// laid out after the edges, the same way WriteTo encodes Put's
+	// payload for its own binary layout.
+	var values []byte
+	for _, rec := range recs {
+		if rec.n.value == nil {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(gobValue{V: rec.n.value}); err != nil {
+			return err
+		}
+		rec.value = buf.Bytes()
+		values = append(values, rec.value...)
+	}
+
+	buf := make([]byte, 0, mmapHeaderSize+len(recs)*mmapNodeSize+len(edges)*mmapEdgeSize+len(values))
+	buf = appendU32(buf, mmapMagic)
+	buf = append(buf, byte(mmapVersion), byte(t.root.mode))
+	buf = appendU32(buf, uint32(len(recs)))
+	buf = appendU32(buf, uint32(len(edges)))
+	buf = appendU32(buf, 0) // root is always the first node in pre-order
+
+	var valueOff uint32
+	for _, rec := range recs {
+		buf = appendI32(buf, int32(rec.n.val))
+		if rec.n.term {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		buf = appendU64(buf, rec.n.mask)
+		buf = appendU32(buf, rec.childOff)
+		buf = appendU32(buf, rec.childCount)
+
+		if rec.value == nil {
+			buf = append(buf, 0)
+			buf = appendU32(buf, 0)
+			buf = appendU32(buf, 0)
+			continue
+		}
+		buf = append(buf, 1)
+		buf = appendU32(buf, valueOff)
+		buf = appendU32(buf, uint32(len(rec.value)))
+		valueOff += uint32(len(rec.value))
+	}
+
+	for _, e := range edges {
+		buf = appendU32(buf, e[0])
+		buf = appendU32(buf, e[1])
+	}
+
+	buf = append(buf, values...)
+
+	_, err = f.Write(buf)
+	return err
+}
+
+func appendU32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendI32(buf []byte, v int32) []byte {
+	return appendU32(buf, uint32(v))
+}
+
+func appendU64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// LoadMmap memory-maps the trie file at path and decodes it into a
+// regular, fully-featured *Trie: mmap is used only to pull the bytes
+// in without a separate read() copy, so load time still avoids
+// rebuilding the trie key by key the way AddFromFile does.
+func LoadMmap(path string) (*Trie, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Munmap(data)
+
+	if len(data) < mmapHeaderSize || binary.LittleEndian.Uint32(data[0:4]) != mmapMagic {
+		return nil, fmt.Errorf("trie: not a valid mmap trie file")
+	}
+	if data[4] != mmapVersion {
+		return nil, fmt.Errorf("trie: unsupported mmap version %d", data[4])
+	}
+
+	mode := MaskMode(data[5])
+	nodeCount := int(binary.LittleEndian.Uint32(data[6:10]))
+	edgeCount := int(binary.LittleEndian.Uint32(data[10:14]))
+	rootIdx := binary.LittleEndian.Uint32(data[14:18])
+	valuesBase := mmapHeaderSize + nodeCount*mmapNodeSize + edgeCount*mmapEdgeSize
+
+	if len(data) < valuesBase {
+		return nil, fmt.Errorf("trie: truncated mmap trie file: have %d bytes, need at least %d for the node and edge tables", len(data), valuesBase)
+	}
+	if rootIdx >= uint32(nodeCount) {
+		return nil, fmt.Errorf("trie: root index %d out of range (have %d nodes)", rootIdx, nodeCount)
+	}
+
+	size := 0
+	root, err := decodeMmapNode(data, nodeCount, edgeCount, valuesBase, rootIdx, mode, nil, &size)
+	if err != nil {
+		return nil, err
+	}
+	return &Trie{root: root, size: size}, nil
+}
+
+func mmapNodeField(data []byte, nodeCount int, idx uint32) (val int32, term bool, mask uint64, childOff, childCount uint32, hasValue bool, valueOff, valueLen uint32, err error) {
+	if idx >= uint32(nodeCount) {
+		err = fmt.Errorf("trie: node index %d out of range (have %d nodes)", idx, nodeCount)
+		return
+	}
+
+	base := mmapHeaderSize + int(idx)*mmapNodeSize
+	if base+mmapNodeSize > len(data) {
+		err = fmt.Errorf("trie: truncated mmap trie file: node %d record extends past end of data", idx)
+		return
+	}
+
+	rec := data[base : base+mmapNodeSize]
+	val = int32(binary.LittleEndian.Uint32(rec[0:4]))
+	term = rec[4] == 1
+	mask = binary.LittleEndian.Uint64(rec[5:13])
+	childOff = binary.LittleEndian.Uint32(rec[13:17])
+	childCount = binary.LittleEndian.Uint32(rec[17:21])
+	hasValue = rec[21] == 1
+	valueOff = binary.LittleEndian.Uint32(rec[22:26])
+	valueLen = binary.LittleEndian.Uint32(rec[26:30])
+	return
+}
+
+func mmapEdgeAt(data []byte, nodeCount, edgeCount int, i uint32) (r rune, idx uint32, err error) {
+	if i >= uint32(edgeCount) {
+		err = fmt.Errorf("trie: edge index %d out of range (have %d edges)", i, edgeCount)
+		return
+	}
+
+	base := mmapHeaderSize + nodeCount*mmapNodeSize + int(i)*mmapEdgeSize
+	if base+mmapEdgeSize > len(data) {
+		err = fmt.Errorf("trie: truncated mmap trie file: edge %d extends past end of data", i)
+		return
+	}
+
+	rec := data[base : base+mmapEdgeSize]
+	return rune(binary.LittleEndian.Uint32(rec[0:4])), binary.LittleEndian.Uint32(rec[4:8]), nil
+}
+
+func decodeMmapNode(data []byte, nodeCount, edgeCount, valuesBase int, idx uint32, mode MaskMode, parent *Node, size *int) (*Node, error) {
+	val, term, mask, childOff, childCount, hasValue, valueOff, valueLen, err := mmapNodeField(data, nodeCount, idx)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(childOff)+uint64(childCount) > uint64(edgeCount) {
+		return nil, fmt.Errorf("trie: node %d child range [%d, %d) exceeds %d edges", idx, childOff, uint64(childOff)+uint64(childCount), edgeCount)
+	}
+
+	n := newNode(parent, rune(val), mask, term)
+	n.mode = mode
+	if term {
+		*size++
+	}
+
+	if hasValue {
+		start := valuesBase + int(valueOff)
+		end := start + int(valueLen)
+		if int(valueOff) > len(data)-valuesBase || end > len(data) {
+			return nil, fmt.Errorf("trie: node %d value [%d, %d) extends past end of data", idx, start, end)
+		}
+
+		var gv gobValue
+		if err := gob.NewDecoder(bytes.NewReader(data[start:end])).Decode(&gv); err != nil {
+			return nil, err
+		}
+		n.value = gv.V
+	}
+
+	for i := uint32(0); i < childCount; i++ {
+		r, cidx, err := mmapEdgeAt(data, nodeCount, edgeCount, childOff+i)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := decodeMmapNode(data, nodeCount, edgeCount, valuesBase, cidx, mode, n, size)
+		if err != nil {
+			return nil, err
+		}
+		n.children[r] = c
+	}
+
+	return n, nil
+}
@@ -0,0 +1,117 @@
+//go:build !windows
+
+package trie
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestSaveLoadMmapRoundTrip(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"cat", "car", "dog", "apple", "application"} {
+		tr.Add(w)
+	}
+	tr.Put("cat", 42)
+	tr.Put("dog", "woof")
+
+	path := filepath.Join(t.TempDir(), "trie.mmap")
+	if err := tr.SaveMmap(path); err != nil {
+		t.Fatalf("SaveMmap: %v", err)
+	}
+
+	got, err := LoadMmap(path)
+	if err != nil {
+		t.Fatalf("LoadMmap: %v", err)
+	}
+
+	want := tr.Keys()
+	sort.Strings(want)
+	have := got.Keys()
+	sort.Strings(have)
+	if len(want) != len(have) {
+		t.Fatalf("Keys() after mmap round-trip = %v, want %v", have, want)
+	}
+	for i := range want {
+		if want[i] != have[i] {
+			t.Fatalf("Keys() after mmap round-trip = %v, want %v", have, want)
+		}
+	}
+
+	if v, ok := got.Get("cat"); !ok || v.(int) != 42 {
+		t.Fatalf("Get(cat) after mmap round-trip = (%v, %v), want (42, true)", v, ok)
+	}
+	if v, ok := got.Get("dog"); !ok || v.(string) != "woof" {
+		t.Fatalf("Get(dog) after mmap round-trip = (%v, %v), want (woof, true)", v, ok)
+	}
+	if _, ok := got.Get("missing"); ok {
+		t.Fatalf("Get(missing) after mmap round-trip found a value for a key never added")
+	}
+}
+
+func TestLoadMmapRejectsCorruptNodeCount(t *testing.T) {
+	tr := CreateTrie()
+	tr.Add("cat")
+	tr.Add("dog")
+
+	path := filepath.Join(t.TempDir(), "trie.mmap")
+	if err := tr.SaveMmap(path); err != nil {
+		t.Fatalf("SaveMmap: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	// Inflate the node-count field (bytes 6:10) far past what the file
+	// actually holds, the way truncation or disk corruption would.
+	binary.LittleEndian.PutUint32(data[6:10], 1<<20)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := LoadMmap(path); err == nil {
+		t.Fatalf("LoadMmap accepted a file with an inflated node count instead of erroring")
+	}
+}
+
+func TestLoadMmapRejectsTruncatedFile(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"cat", "car", "dog", "apple", "application"} {
+		tr.Add(w)
+	}
+
+	path := filepath.Join(t.TempDir(), "trie.mmap")
+	if err := tr.SaveMmap(path); err != nil {
+		t.Fatalf("SaveMmap: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	truncated := filepath.Join(t.TempDir(), "truncated.mmap")
+	if err := os.WriteFile(truncated, data[:len(data)/2], 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := LoadMmap(truncated); err == nil {
+		t.Fatalf("LoadMmap accepted a truncated file instead of erroring")
+	}
+}
+
+func TestLoadMmapRejectsBadMagic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.mmap")
+	if err := os.WriteFile(path, []byte("not a trie file, but long enough to pass the header length check"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := LoadMmap(path); err == nil {
+		t.Fatalf("LoadMmap accepted a file with a bad magic number")
+	}
+}
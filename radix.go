@@ -0,0 +1,284 @@
+package trie
+
+import "sort"
+
+// radixNode is a single edge-compressed node in a RadixTrie. Unlike
+// Node, which stores exactly one rune per edge, a radixNode's label
+// may span several runes: maximal chains of single-child, non-terminal
+// Nodes are collapsed into one edge, as in a Patricia tree.
+type radixNode struct {
+	label    []rune
+	term     bool
+	mask     uint64
+	mode     MaskMode
+	parent   *radixNode
+	children map[rune]*radixNode
+}
+
+// newRadixNode creates a child of parent, inheriting parent's mask
+// mode; pass a nil parent only for the root, whose mode the caller
+// sets directly afterward.
+func newRadixNode(parent *radixNode, label []rune, term bool) *radixNode {
+	n := &radixNode{
+		label:    label,
+		term:     term,
+		parent:   parent,
+		children: make(map[rune]*radixNode),
+	}
+	if parent != nil {
+		n.mode = parent.mode
+	}
+	n.recalculateMask()
+	return n
+}
+
+// Mask returns the bloom-style bitmask of every rune reachable from
+// this node, inclusive of its own label, OR'd together.
+func (n *radixNode) Mask() uint64 {
+	return n.mask
+}
+
+func (n *radixNode) recalculateMask() {
+	n.mask = maskruneslice(n.mode, n.label)
+	for _, c := range n.children {
+		n.mask |= c.Mask()
+	}
+}
+
+func (n *radixNode) bubbleMask() {
+	for c := n; c != nil; c = c.parent {
+		c.recalculateMask()
+	}
+}
+
+// RadixTrie is a Patricia-style compressed trie: maximal chains of
+// single-child, non-terminal Nodes are collapsed into a single edge
+// labeled by a rune slice, trading away per-rune branching for far
+// fewer pointer chases on sparse keys such as "internationalization".
+type RadixTrie struct {
+	root *radixNode
+	size int
+}
+
+// CreateRadixTrie creates and returns a new, empty RadixTrie. The
+// trie uses ASCIIMask, under which only runes from 'a' to U+00A0 help
+// FuzzySearch prune subtrees; use CreateRadixTrieMode for other keys.
+func CreateRadixTrie() *RadixTrie {
+	return CreateRadixTrieMode(ASCIIMask)
+}
+
+// CreateRadixTrieMode creates a new, empty RadixTrie whose pruning
+// masks are computed according to mode. Use UnicodeMask when keys may
+// contain runes outside lowercase ASCII a-z.
+func CreateRadixTrieMode(mode MaskMode) *RadixTrie {
+	root := newRadixNode(nil, nil, false)
+	root.mode = mode
+	return &RadixTrie{root: root}
+}
+
+func commonPrefixLen(a, b []rune) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// Add inserts key into the trie, splitting an existing edge when key
+// shares only a proper prefix of that edge's label.
+func (t *RadixTrie) Add(key string) {
+	runes := []rune(key)
+	node := t.root
+
+	for {
+		if len(runes) == 0 {
+			if !node.term {
+				t.size++
+			}
+			node.term = true
+			return
+		}
+
+		child, ok := node.children[runes[0]]
+		if !ok {
+			leaf := newRadixNode(node, runes, true)
+			node.children[runes[0]] = leaf
+			t.size++
+			leaf.bubbleMask()
+			return
+		}
+
+		lcp := commonPrefixLen(runes, child.label)
+		if lcp == len(child.label) {
+			node = child
+			runes = runes[lcp:]
+			continue
+		}
+
+		// child's label diverges from the key part way through;
+		// split the edge at the point of divergence.
+		mid := newRadixNode(node, child.label[:lcp], false)
+		child.parent = mid
+		child.label = child.label[lcp:]
+		child.recalculateMask()
+		mid.children[child.label[0]] = child
+		node.children[runes[0]] = mid
+
+		if rest := runes[lcp:]; len(rest) == 0 {
+			mid.term = true
+		} else {
+			leaf := newRadixNode(mid, rest, true)
+			mid.children[rest[0]] = leaf
+		}
+
+		t.size++
+		mid.bubbleMask()
+		return
+	}
+}
+
+// Remove deletes key from the trie, reporting whether it was present.
+// A node that becomes non-terminal with exactly one remaining child is
+// re-merged with that child, and a node left with no children of its
+// own is dropped, keeping every chain maximally compressed.
+func (t *RadixTrie) Remove(key string) bool {
+	runes := []rune(key)
+	node := t.root
+
+	for len(runes) > 0 {
+		child, ok := node.children[runes[0]]
+		if !ok || commonPrefixLen(runes, child.label) != len(child.label) {
+			return false
+		}
+		runes = runes[len(child.label):]
+		node = child
+	}
+
+	if !node.term {
+		return false
+	}
+
+	node.term = false
+	t.size--
+	t.prune(node)
+	return true
+}
+
+// prune re-merges or drops node and its compressible ancestors after a
+// removal.
+func (t *RadixTrie) prune(node *radixNode) {
+	for node != t.root {
+		parent := node.parent
+
+		if node.term || len(node.children) > 1 {
+			break
+		}
+
+		if len(node.children) == 0 {
+			delete(parent.children, node.label[0])
+			node = parent
+			continue
+		}
+
+		var only *radixNode
+		for _, c := range node.children {
+			only = c
+		}
+		only.label = append(append([]rune{}, node.label...), only.label...)
+		only.parent = parent
+		parent.children[only.label[0]] = only
+		node = only
+		break
+	}
+
+	node.bubbleMask()
+}
+
+// Keys returns all the keys currently stored in the trie.
+func (t *RadixTrie) Keys() []string {
+	return t.PrefixSearch("")
+}
+
+// PrefixSearch performs a prefix search against the keys in the trie.
+func (t *RadixTrie) PrefixSearch(pre string) []string {
+	var keys []string
+
+	remaining := []rune(pre)
+	node := t.root
+	acc := make([]rune, 0, len(remaining))
+
+	for len(remaining) > 0 {
+		child, ok := node.children[remaining[0]]
+		if !ok {
+			return keys
+		}
+
+		lcp := commonPrefixLen(remaining, child.label)
+		if lcp == len(remaining) {
+			// The prefix ends inside, or exactly at, this edge.
+			acc = append(acc, child.label...)
+			radixcollect(child, acc, &keys)
+			return keys
+		}
+		if lcp != len(child.label) {
+			return keys
+		}
+
+		acc = append(acc, child.label...)
+		node = child
+		remaining = remaining[lcp:]
+	}
+
+	radixcollect(node, acc, &keys)
+	return keys
+}
+
+func radixcollect(node *radixNode, pre []rune, keys *[]string) {
+	if node.term {
+		*keys = append(*keys, string(pre))
+	}
+	for _, c := range node.children {
+		radixcollect(c, append(append([]rune{}, pre...), c.label...), keys)
+	}
+}
+
+// FuzzySearch performs a fuzzy search against the keys in the trie.
+func (t *RadixTrie) FuzzySearch(pre string) []string {
+	var keys []string
+	radixfuzzycollect(t.root, nil, []rune(pre), &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+func radixfuzzycollect(node *radixNode, pre, partial []rune, keys *[]string) {
+	if len(partial) == 0 {
+		radixcollect(node, pre, keys)
+		return
+	}
+
+	m := maskruneslice(node.mode, partial)
+	for _, c := range node.children {
+		if m&^c.Mask() != 0 {
+			continue
+		}
+		npre := append(append([]rune{}, pre...), c.label...)
+		radixfuzzycollect(c, npre, consumeLabel(c.label, partial), keys)
+	}
+}
+
+// consumeLabel advances partial past every rune of label that matches
+// its current front rune, mirroring the match-or-skip rule fuzzycollect
+// applies one rune at a time.
+func consumeLabel(label, partial []rune) []rune {
+	for _, v := range label {
+		if len(partial) > 0 && v == partial[0] {
+			partial = partial[1:]
+		}
+	}
+	return partial
+}
@@ -0,0 +1,76 @@
+package trie
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRadixTrieAddAndSearch(t *testing.T) {
+	tr := CreateRadixTrie()
+	words := []string{"internationalization", "international", "inter", "interval", "banana"}
+	for _, w := range words {
+		tr.Add(w)
+	}
+
+	got := tr.Keys()
+	sort.Strings(got)
+	want := append([]string{}, words...)
+	sort.Strings(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+
+	for _, w := range words {
+		pre := w[:len(w)-1]
+		found := false
+		for _, k := range tr.PrefixSearch(pre) {
+			if k == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("PrefixSearch(%q) missing %q", pre, w)
+		}
+	}
+}
+
+func TestRadixTrieRemove(t *testing.T) {
+	tr := CreateRadixTrie()
+	tr.Add("internationalization")
+	tr.Add("international")
+
+	if !tr.Remove("internationalization") {
+		t.Fatalf("Remove reported key missing")
+	}
+
+	got := tr.Keys()
+	if len(got) != 1 || got[0] != "international" {
+		t.Fatalf("Keys() after Remove = %v, want [international]", got)
+	}
+
+	if tr.Remove("nonexistent") {
+		t.Fatalf("Remove reported success for a key never added")
+	}
+}
+
+func TestRadixTrieUnicodeMode(t *testing.T) {
+	tr := CreateRadixTrieMode(UnicodeMask)
+	words := []string{"café", "cartel", "日本語"}
+	for _, w := range words {
+		tr.Add(w)
+	}
+
+	for _, w := range words {
+		found := false
+		for _, k := range tr.FuzzySearch(w) {
+			if k == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("FuzzySearch(%q) did not find itself under UnicodeMask", w)
+		}
+	}
+}
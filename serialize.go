@@ -0,0 +1,216 @@
+package trie
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+const (
+	binMagic   uint32 = 0x54524931 // "TRI1"
+	binVersion uint8  = 2
+)
+
+// gobValue wraps a Node's value for gob encoding. gob only preserves a
+// value's concrete type across Encode/Decode when it arrives through
+// a field declared as an interface; encoding a bare interface{}
+// argument to Encode loses that and fails to decode back into one.
+type gobValue struct {
+	V any
+}
+
+// WriteTo serializes the trie to w in a compact binary layout: a
+// header (magic, version, mask mode), followed by a pre-order stream
+// of nodes. Each node records its rune, terminal flag, and mask,
+// followed by its gob-encoded Value (if any set by Put), its child
+// count, and, recursively, its children. It satisfies io.WriterTo.
+//
+// If any stored value is of a concrete type other than the predeclared
+// basic types, call gob.Register on it before WriteTo or ReadTrie so
+// encoding/gob can identify it; see gob.Register's documentation.
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+
+	if err := binary.Write(cw, binary.LittleEndian, binMagic); err != nil {
+		return cw.n, err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, binVersion); err != nil {
+		return cw.n, err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, uint8(t.root.mode)); err != nil {
+		return cw.n, err
+	}
+	if err := writeNode(cw, t.root); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, bw.Flush()
+}
+
+func writeNode(w io.Writer, n *Node) error {
+	if err := binary.Write(w, binary.LittleEndian, int32(n.val)); err != nil {
+		return err
+	}
+
+	var term uint8
+	if n.term {
+		term = 1
+	}
+	if err := binary.Write(w, binary.LittleEndian, term); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, n.mask); err != nil {
+		return err
+	}
+
+	var hasValue uint8
+	var encoded []byte
+	if n.value != nil {
+		hasValue = 1
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(gobValue{V: n.value}); err != nil {
+			return err
+		}
+		encoded = buf.Bytes()
+	}
+	if err := binary.Write(w, binary.LittleEndian, hasValue); err != nil {
+		return err
+	}
+	if hasValue == 1 {
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(encoded))); err != nil {
+			return err
+		}
+		if _, err := w.Write(encoded); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(n.children))); err != nil {
+		return err
+	}
+
+	for _, c := range n.children {
+		if err := writeNode(w, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadTrie reconstructs a trie previously written by (*Trie).WriteTo.
+func ReadTrie(r io.Reader) (*Trie, error) {
+	br := bufio.NewReader(r)
+
+	var magic uint32
+	if err := binary.Read(br, binary.LittleEndian, &magic); err != nil {
+		return nil, err
+	}
+	if magic != binMagic {
+		return nil, fmt.Errorf("trie: bad magic number %#x", magic)
+	}
+
+	var version uint8
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != binVersion {
+		return nil, fmt.Errorf("trie: unsupported version %d", version)
+	}
+
+	var mode uint8
+	if err := binary.Read(br, binary.LittleEndian, &mode); err != nil {
+		return nil, err
+	}
+
+	root, size, err := readNode(br, nil, MaskMode(mode))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Trie{root: root, size: size}, nil
+}
+
+func readNode(r io.Reader, parent *Node, mode MaskMode) (*Node, int, error) {
+	var val int32
+	if err := binary.Read(r, binary.LittleEndian, &val); err != nil {
+		return nil, 0, err
+	}
+
+	var term uint8
+	if err := binary.Read(r, binary.LittleEndian, &term); err != nil {
+		return nil, 0, err
+	}
+
+	var mask uint64
+	if err := binary.Read(r, binary.LittleEndian, &mask); err != nil {
+		return nil, 0, err
+	}
+
+	var hasValue uint8
+	if err := binary.Read(r, binary.LittleEndian, &hasValue); err != nil {
+		return nil, 0, err
+	}
+
+	var value any
+	if hasValue == 1 {
+		var valLen uint32
+		if err := binary.Read(r, binary.LittleEndian, &valLen); err != nil {
+			return nil, 0, err
+		}
+
+		encoded := make([]byte, valLen)
+		if _, err := io.ReadFull(r, encoded); err != nil {
+			return nil, 0, err
+		}
+
+		var gv gobValue
+		if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&gv); err != nil {
+			return nil, 0, err
+		}
+		value = gv.V
+	}
+
+	var childCount uint32
+	if err := binary.Read(r, binary.LittleEndian, &childCount); err != nil {
+		return nil, 0, err
+	}
+
+	n := newNode(parent, rune(val), mask, term == 1)
+	n.mode = mode
+	n.value = value
+
+	size := 0
+	if n.term {
+		size = 1
+	}
+
+	for i := uint32(0); i < childCount; i++ {
+		c, csize, err := readNode(r, n, mode)
+		if err != nil {
+			return nil, 0, err
+		}
+		n.children[c.val] = c
+		size += csize
+	}
+
+	return n, size, nil
+}
+
+// countingWriter tracks the number of bytes written through it, so
+// WriteTo can report its io.WriterTo byte count even though it writes
+// through a bufio.Writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,55 @@
+package trie
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+func TestWriteToReadTrieRoundTrip(t *testing.T) {
+	tr := CreateTrie()
+	for _, w := range []string{"cat", "car", "dog", "apple", "application"} {
+		tr.Add(w)
+	}
+	tr.Put("cat", 42)
+	tr.Put("dog", "woof")
+
+	var buf bytes.Buffer
+	if _, err := tr.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	got, err := ReadTrie(&buf)
+	if err != nil {
+		t.Fatalf("ReadTrie: %v", err)
+	}
+
+	want := tr.Keys()
+	sort.Strings(want)
+	have := got.Keys()
+	sort.Strings(have)
+	if len(want) != len(have) {
+		t.Fatalf("Keys() after round-trip = %v, want %v", have, want)
+	}
+	for i := range want {
+		if want[i] != have[i] {
+			t.Fatalf("Keys() after round-trip = %v, want %v", have, want)
+		}
+	}
+
+	if v, ok := got.Get("cat"); !ok || v.(int) != 42 {
+		t.Fatalf("Get(cat) after round-trip = (%v, %v), want (42, true)", v, ok)
+	}
+	if v, ok := got.Get("dog"); !ok || v.(string) != "woof" {
+		t.Fatalf("Get(dog) after round-trip = (%v, %v), want (woof, true)", v, ok)
+	}
+	if _, ok := got.Get("missing"); ok {
+		t.Fatalf("Get(missing) after round-trip found a value for a key never added")
+	}
+}
+
+func TestReadTrieRejectsBadMagic(t *testing.T) {
+	if _, err := ReadTrie(bytes.NewReader([]byte("not a trie"))); err == nil {
+		t.Fatalf("ReadTrie accepted a stream with a bad magic number")
+	}
+}
@@ -0,0 +1,276 @@
+package trie
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SyncTrie wraps a Trie for concurrent use. Mutating calls (Add,
+// Remove, Put, Delete) are serialized against each other by mu, but
+// build their change as a new tree that shares every untouched
+// subtree with the old one (copy-on-write along the path from the
+// root to the mutated node) and then swap it into root with a single
+// atomic.Pointer store. Read calls (Get, Keys, PrefixSearch,
+// FuzzySearch, Snapshot) load root without taking mu at all, so they
+// never block on, or are blocked by, a concurrent writer: they simply
+// see either the tree as it was before the write or the tree as it is
+// after, never a torn state in between.
+type SyncTrie struct {
+	mu   sync.Mutex // serializes writers only; readers never take it
+	root atomic.Pointer[Node]
+	size atomic.Int64
+}
+
+// NewSyncTrie wraps t for concurrent use. t must not be accessed
+// directly once it has been handed to a SyncTrie.
+func NewSyncTrie(t *Trie) *SyncTrie {
+	s := &SyncTrie{}
+	s.root.Store(t.root)
+	s.size.Store(int64(t.size))
+	return s
+}
+
+// view returns a *Trie over the tree root currently points at. The
+// returned *Trie is read-only: its root is never mutated in place,
+// only ever replaced wholesale by a writer.
+func (s *SyncTrie) view() *Trie {
+	return &Trie{root: s.root.Load(), size: int(s.size.Load())}
+}
+
+// Adds the key to the Trie.
+func (s *SyncTrie) Add(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runes := []rune(key)
+	s.root.Store(cowAdd(s.root.Load(), runes))
+	s.size.Add(1)
+	return len(runes)
+}
+
+// Removes a key from the trie.
+func (s *SyncTrie) Remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs := []rune(key)
+	newRoot, path := clonePath(s.root.Load(), rs)
+	if len(path) == len(rs)+1 {
+		cutEdge(path, rs)
+	}
+	s.root.Store(newRoot)
+	s.size.Add(-1)
+}
+
+// Put inserts value under key, overwriting any value already stored
+// there, and reports whether key was new to the trie.
+func (s *SyncTrie) Put(key string, value any) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newRoot, isNew := cowPut(s.root.Load(), []rune(key), value)
+	s.root.Store(newRoot)
+	if isNew {
+		s.size.Add(1)
+	}
+	return isNew
+}
+
+// Delete removes key's value from the trie and reports whether key
+// was present.
+func (s *SyncTrie) Delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs := []rune(key)
+	newRoot, path := clonePath(s.root.Load(), rs)
+	if len(path) != len(rs)+1 {
+		return false
+	}
+
+	node := path[len(rs)]
+	if _, ok := node.children[0]; !ok {
+		return false
+	}
+	delete(node.children, 0)
+	recalcUp(node)
+
+	if len(node.children) == 0 {
+		cutEdge(path, rs)
+	}
+
+	s.root.Store(newRoot)
+	s.size.Add(-1)
+	return true
+}
+
+// Get returns the value stored under key, if any.
+func (s *SyncTrie) Get(key string) (any, bool) {
+	return s.view().Get(key)
+}
+
+// Returns all the keys currently stored in the trie.
+func (s *SyncTrie) Keys() []string {
+	return s.view().Keys()
+}
+
+// Performs a prefix search against the keys in the trie.
+func (s *SyncTrie) PrefixSearch(pre string) []string {
+	return s.view().PrefixSearch(pre)
+}
+
+// Performs a fuzzy search against the keys in the trie.
+func (s *SyncTrie) FuzzySearch(pre string) []string {
+	return s.view().FuzzySearch(pre)
+}
+
+// Snapshot returns an immutable view of the trie's current contents
+// that is safe to query from any number of goroutines with no further
+// synchronization, even while writers keep mutating the live SyncTrie
+// concurrently. It costs two atomic loads (root and size): writers never mutate a
+// node already reachable from a published root, they clone the path
+// down to the node they're changing and swap the new root in with
+// root.Store, so a *Trie handed out by Snapshot can never observe a
+// write partway through.
+func (s *SyncTrie) Snapshot() *Trie {
+	return s.view()
+}
+
+// cloneNode returns a shallow copy of n: the same scalar fields, and a
+// new children map populated with n's own entries (the children
+// themselves are shared, not copied). A caller may freely add, remove,
+// or repoint entries in the clone's map without disturbing n or any
+// reader still holding it.
+func cloneNode(n *Node) *Node {
+	c := *n
+	c.children = make(map[rune]*Node, len(n.children))
+	for r, ch := range n.children {
+		c.children[r] = ch
+	}
+	return &c
+}
+
+// clonePath walks from root following runes, the same way findNode
+// does, but clones one node per step instead of just traversing:
+// path[0] is a clone of root, and each subsequent clone is wired as
+// the previous one's child, so a caller can mutate any element of path
+// without touching any node reachable from root itself.
+// It returns early, with a short path, if the key isn't fully present
+// -- exactly where findNode would have returned nil.
+func clonePath(root *Node, runes []rune) (newRoot *Node, path []*Node) {
+	clone := cloneNode(root)
+	path = []*Node{clone}
+
+	cur, orig := clone, root
+	for _, r := range runes {
+		child, ok := orig.children[r]
+		if !ok {
+			break
+		}
+
+		c := cloneNode(child)
+		c.parent = cur
+		cur.children[r] = c
+
+		path = append(path, c)
+		cur, orig = c, child
+	}
+
+	return clone, path
+}
+
+// cutEdge mirrors the edge-collapsing loop shared by Trie's Remove and
+// Delete: starting from the deepest node in path and walking back up
+// towards the root, it deletes the first child edge whose parent has
+// more than one child, then recalculates masks from there up to the
+// root. path must be the result of clonePath for the same runes.
+func cutEdge(path []*Node, runes []rune) {
+	for idx := len(runes) - 1; idx >= 0; idx-- {
+		n := path[idx]
+		if len(n.children) > 1 {
+			delete(n.children, runes[idx])
+			recalcUp(n)
+			return
+		}
+	}
+}
+
+// recalcUp recalculates n's mask and then every ancestor's, walking up
+// via parent pointers, the same way RemoveChild does for a live Trie.
+func recalcUp(n *Node) {
+	for ; n != nil; n = n.parent {
+		n.recalculateMask()
+	}
+}
+
+// cowAdd returns a new root equal to orig with runes inserted, cloning
+// only the nodes on the path to the new (or updated) terminal marker;
+// every sibling subtree is shared with orig, unmodified.
+func cowAdd(orig *Node, runes []rune) *Node {
+	clone := cloneNode(orig)
+
+	if len(runes) == 0 {
+		if _, ok := clone.children[0]; !ok {
+			term := newNode(clone, 0, 0, true)
+			term.mode = clone.mode
+			clone.children[0] = term
+		}
+		return clone
+	}
+
+	r := runes[0]
+	bitmask := maskruneslice(clone.mode, runes)
+
+	child, ok := clone.children[r]
+	if !ok {
+		child = newNode(clone, r, 0, false)
+		child.mode = clone.mode
+	}
+
+	newChild := cowAdd(child, runes[1:])
+	newChild.mask |= bitmask
+	newChild.parent = clone
+	clone.children[r] = newChild
+
+	return clone
+}
+
+// cowPut is cowAdd's counterpart for Put: it threads value down to the
+// terminal marker and reports whether key was new, the same way
+// putrune does for a live Trie.
+func cowPut(orig *Node, runes []rune, value any) (*Node, bool) {
+	clone := cloneNode(orig)
+
+	if len(runes) == 0 {
+		term, ok := clone.children[0]
+		if !ok {
+			term = newNode(clone, 0, 0, true)
+			term.mode = clone.mode
+			term.value = value
+			clone.children[0] = term
+			return clone, true
+		}
+
+		newTerm := cloneNode(term)
+		newTerm.parent = clone
+		newTerm.value = value
+		clone.children[0] = newTerm
+		return clone, false
+	}
+
+	r := runes[0]
+	bitmask := maskruneslice(clone.mode, runes)
+
+	child, ok := clone.children[r]
+	if !ok {
+		child = newNode(clone, r, 0, false)
+		child.mode = clone.mode
+	}
+
+	newChild, isNew := cowPut(child, runes[1:], value)
+	newChild.mask |= bitmask
+	newChild.parent = clone
+	clone.children[r] = newChild
+
+	return clone, isNew
+}
@@ -0,0 +1,86 @@
+package trie
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncTrieConcurrentAccess(t *testing.T) {
+	s := NewSyncTrie(CreateTrie())
+
+	const n = 200
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			s.Put(fmt.Sprintf("key%d", i), i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			s.Add(fmt.Sprintf("word%d", i))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			s.Get(fmt.Sprintf("key%d", i))
+			s.Keys()
+			s.PrefixSearch("key")
+			s.FuzzySearch("k")
+			s.Snapshot()
+		}
+	}()
+
+	wg.Wait()
+
+	if v, ok := s.Get("key0"); !ok || v.(int) != 0 {
+		t.Fatalf("Get(key0) = (%v, %v), want (0, true)", v, ok)
+	}
+}
+
+func TestSyncTrieSnapshotIsUnaffectedByLaterWrites(t *testing.T) {
+	s := NewSyncTrie(CreateTrie())
+	s.Put("cat", 1)
+
+	snap := s.Snapshot()
+
+	s.Put("cat", 2)
+	s.Put("dog", 3)
+	s.Remove("cat")
+
+	if v, ok := snap.Get("cat"); !ok || v.(int) != 1 {
+		t.Fatalf("snapshot Get(cat) = (%v, %v), want (1, true), snapshot must not see later writes", v, ok)
+	}
+	if _, ok := snap.Get("dog"); ok {
+		t.Fatalf("snapshot Get(dog) found a value added after the snapshot was taken")
+	}
+
+	if v, ok := s.Get("cat"); ok {
+		t.Fatalf("live trie Get(cat) = (%v, %v), want ok=false after Remove", v, ok)
+	}
+	if v, ok := s.Get("dog"); !ok || v.(int) != 3 {
+		t.Fatalf("live trie Get(dog) = (%v, %v), want (3, true)", v, ok)
+	}
+}
+
+func TestSyncTrieAddRemove(t *testing.T) {
+	s := NewSyncTrie(CreateTrie())
+	s.Add("cat")
+	s.Add("car")
+
+	keys := s.PrefixSearch("ca")
+	if len(keys) != 2 {
+		t.Fatalf("PrefixSearch(ca) = %v, want 2 keys", keys)
+	}
+
+	s.Remove("cat")
+	keys = s.PrefixSearch("ca")
+	if len(keys) != 1 || keys[0] != "car" {
+		t.Fatalf("PrefixSearch(ca) after Remove = %v, want [car]", keys)
+	}
+}
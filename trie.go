@@ -19,6 +19,8 @@ type Node struct {
 	val      rune
 	term     bool
 	mask     uint64
+	mode     MaskMode
+	value    any
 	parent   *Node
 	children map[rune]*Node
 }
@@ -40,9 +42,11 @@ func newNode(parent *Node, val rune, m uint64, term bool) *Node {
 	}
 }
 
-// Creates and returns a pointer to a new child for the node.
+// Creates and returns a pointer to a new child for the node. The
+// child inherits its mask mode from the receiver.
 func (n *Node) NewChild(parent *Node, r rune, bitmask uint64, val rune, term bool) *Node {
 	node := newNode(parent, val, bitmask, term)
+	node.mode = n.mode
 	n.children[r] = node
 	return node
 }
@@ -57,9 +61,9 @@ func (n *Node) RemoveChild(r rune) {
 }
 
 func (n *Node) recalculateMask() {
-	n.mask = maskrune(n.Val())
+	n.mask = maskrune(n.mode, n.Val())
 	for k, c := range n.Children() {
-		n.mask |= (maskrune(k) | c.Mask())
+		n.mask |= (maskrune(n.mode, k) | c.Mask())
 	}
 }
 
@@ -83,9 +87,24 @@ func (n Node) Mask() uint64 {
 	return n.mask
 }
 
-// Creates a new Trie with an initialized root Node.
+// Returns the value stored on this node, if any, as set by Put.
+func (n Node) Value() any {
+	return n.value
+}
+
+// Creates a new Trie with an initialized root Node. The trie uses
+// ASCIIMask, under which only runes from 'a' to U+00A0 help
+// FuzzySearch prune subtrees; use CreateTrieMode for other keys.
 func CreateTrie() *Trie {
+	return CreateTrieMode(ASCIIMask)
+}
+
+// Creates a new Trie whose pruning masks are computed according to
+// mode. Use UnicodeMask when keys may contain runes outside lowercase
+// ASCII a-z.
+func CreateTrieMode(mode MaskMode) *Trie {
 	node := newNode(nil, 0, 0, false)
+	node.mode = mode
 	return &Trie{
 		root: node,
 		size: 0,
@@ -211,7 +230,7 @@ func (t Trie) addrune(node *Node, runes []rune, i int) int {
 	c := node.Children()
 
 	n, ok := c[r]
-	bitmask := maskruneslice(runes)
+	bitmask := maskruneslice(node.mode, runes)
 	if !ok {
 		n = node.NewChild(node, r, bitmask, r, false)
 	}
@@ -221,20 +240,58 @@ func (t Trie) addrune(node *Node, runes []rune, i int) int {
 	return t.addrune(n, runes[1:], i)
 }
 
-func maskruneslice(rs []rune) uint64 {
+// MaskMode selects how a Trie computes each Node's bloom-style
+// pruning mask.
+type MaskMode int
+
+const (
+	// ASCIIMask shifts each rune into a bit by its offset from 'a':
+	// runes 'a' through U+00A0 each get their own bit, and every other
+	// rune (uppercase, digits, most accented letters, CJK, ...) gets an
+	// empty mask, so it never lets FuzzySearch prune a subtree. It is
+	// the fastest mode, and the default kept for backward compatibility.
+	ASCIIMask MaskMode = iota
+
+	// UnicodeMask hashes each rune into one of 64 bits with FNV-1a,
+	// so it works for any rune (uppercase, digits, accents, CJK, ...)
+	// at the cost of a small false-positive rate in FuzzySearch
+	// pruning.
+	UnicodeMask
+)
+
+func maskruneslice(mode MaskMode, rs []rune) uint64 {
 	var m uint64
 	for _, r := range rs {
-		m |= maskrune(r)
+		m |= maskrune(mode, r)
 	}
 
 	return m
 }
 
-func maskrune(r rune) uint64 {
+func maskrune(mode MaskMode, r rune) uint64 {
+	if mode == UnicodeMask {
+		return 1 << (fnv32(r) & 63)
+	}
+
 	i := uint64(1)
 	return i << (uint64(r) - 97)
 }
 
+// fnv32 hashes a single rune with FNV-1a.
+func fnv32(r rune) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+
+	h := uint32(offset32)
+	for _, b := range []byte(string(r)) {
+		h ^= uint32(b)
+		h *= prime32
+	}
+	return h
+}
+
 func collect(node *Node, pre []rune, keys *[]string) {
 	children := node.Children()
 	for r, n := range children {
@@ -256,7 +313,7 @@ func fuzzycollect(node *Node, partialmatch, partial []rune, keys *[]string) {
 		return
 	}
 
-	m := maskruneslice(partial)
+	m := maskruneslice(node.mode, partial)
 	children := node.Children()
 	for v, n := range children {
 		xor := n.Mask() ^ m
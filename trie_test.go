@@ -0,0 +1,31 @@
+package trie
+
+import "testing"
+
+func TestUnicodeMaskSupportsNonASCIIKeys(t *testing.T) {
+	tr := CreateTrieMode(UnicodeMask)
+	words := []string{"café", "cartel", "日本語", "Über"}
+	for _, w := range words {
+		tr.Add(w)
+	}
+
+	for _, w := range words {
+		found := false
+		for _, k := range tr.FuzzySearch(w) {
+			if k == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("FuzzySearch(%q) did not find itself under UnicodeMask", w)
+		}
+	}
+}
+
+func TestASCIIMaskIsDefault(t *testing.T) {
+	tr := CreateTrie()
+	tr.Add("abc")
+	if tr.Root().mode != ASCIIMask {
+		t.Fatalf("CreateTrie root mode = %v, want ASCIIMask", tr.Root().mode)
+	}
+}